@@ -0,0 +1,445 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun"
+	"github.com/cpmech/gosl/io"
+)
+
+// Brent implements Brent's method for finding a root of f(x)=0 (Solve) and for finding
+// a minimum of f(x) (Min) within a given bracketing interval. It combines the reliability
+// of bisection with the speed of secant/inverse-quadratic interpolation (for root finding)
+// or golden-section search with parabolic interpolation (for minimisation).
+type Brent struct {
+
+	// constants
+	MaxIt int     // max iterations
+	Tol   float64 // tolerance
+
+	// stat
+	NFeval int // number of calls to Ffcn (function evaluations)
+	NDeval int // number of calls to the derivative function (MinD only)
+	It     int // number of iterations used
+
+	// data
+	Ffcn fun.Ss // y = f(x)
+}
+
+// Init sets the function and default constants
+func (o *Brent) Init(ffcn fun.Ss) {
+	o.Ffcn = ffcn
+	o.MaxIt = 100
+	o.Tol = 1e-10
+}
+
+// Solve finds the root of f(x) within [xa, xb] using Brent's method.
+// It requires f(xa) and f(xb) to have opposite signs (a valid bracket).
+func (o *Brent) Solve(xa, xb float64, silent bool) (res float64, err error) {
+
+	// evaluate function at the bracket
+	fa, e := o.fcn(xa)
+	if e != nil {
+		return 0, e
+	}
+	fb, e := o.fcn(xb)
+	if e != nil {
+		return 0, e
+	}
+	if fa*fb > 0.0 {
+		return 0, chk.Err("root must be bracketed. f(xa)=%v and f(xb)=%v have the same sign", fa, fb)
+	}
+
+	// auxiliary variables
+	a, b, c := xa, xb, xb
+	fc := fb
+	var d, e2, min1, min2, p, q, r, s, tol1, xm float64
+
+	for o.It = 0; o.It < o.MaxIt; o.It++ {
+
+		// rename so that b is the best estimate so far
+		if (fb > 0.0 && fc > 0.0) || (fb < 0.0 && fc < 0.0) {
+			c, fc = a, fa
+			d = b - a
+			e2 = d
+		}
+		if math.Abs(fc) < math.Abs(fb) {
+			a, b, c = b, c, b
+			fa, fb, fc = fb, fc, fb
+		}
+
+		// convergence check
+		tol1 = 2.0*2.2204460492503131e-16*math.Abs(b) + 0.5*o.Tol
+		xm = 0.5 * (c - b)
+		if math.Abs(xm) <= tol1 || fb == 0.0 {
+			if !silent {
+				io.Pforan("Brent.Solve: converged with %v iterations and %v function evaluations\n", o.It, o.NFeval)
+			}
+			return b, nil
+		}
+
+		// decide between bisection and interpolation
+		if math.Abs(e2) >= tol1 && math.Abs(fa) > math.Abs(fb) {
+			s = fb / fa
+			if a == c {
+				p = 2.0 * xm * s
+				q = 1.0 - s
+			} else {
+				q = fa / fc
+				r = fb / fc
+				p = s * (2.0*xm*q*(q-r) - (b-a)*(r-1.0))
+				q = (q - 1.0) * (r - 1.0) * (s - 1.0)
+			}
+			if p > 0.0 {
+				q = -q
+			}
+			p = math.Abs(p)
+			min1 = 3.0*xm*q - math.Abs(tol1*q)
+			min2 = math.Abs(e2 * q)
+			if 2.0*p < math.Min(min1, min2) {
+				e2 = d
+				d = p / q
+			} else {
+				d = xm
+				e2 = d
+			}
+		} else {
+			d = xm
+			e2 = d
+		}
+
+		// move last best guess to a
+		a, fa = b, fb
+		if math.Abs(d) > tol1 {
+			b += d
+		} else {
+			b += math.Copysign(tol1, xm)
+		}
+		fb, err = o.fcn(b)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return 0, chk.Err("Brent.Solve: after %v iterations, no root found (last estimate: x=%v f(x)=%v)", o.MaxIt, b, fb)
+}
+
+// Bracket searches outward from an initial guess [x1, x2] until a sign change is found,
+// expanding the interval geometrically (golden-ratio-like growth). On each step, the endpoint
+// with the smaller |f| is replaced by stepping further away from the other endpoint:
+//
+//	x_this = x_this + factor*(x_this - x_other)
+//
+// with factor ~1.6. It bails out with a diagnostic error if f evaluates to NaN/Inf or if
+// maxIter is exceeded without finding a sign change.
+func Bracket(f fun.Ss, x1, x2 float64, maxIter int) (a, b float64, err error) {
+	const factor = 1.6
+	if x1 == x2 {
+		return 0, 0, chk.Err("Bracket: x1 and x2 must be different (x1=x2=%v)", x1)
+	}
+	a, b = x1, x2
+	fa, e := f(a)
+	if e != nil {
+		return 0, 0, e
+	}
+	fb, e := f(b)
+	if e != nil {
+		return 0, 0, e
+	}
+	for it := 0; it < maxIter; it++ {
+		if math.IsNaN(fa) || math.IsInf(fa, 0) || math.IsNaN(fb) || math.IsInf(fb, 0) {
+			return 0, 0, chk.Err("Bracket: f(x) is NaN/Inf while searching (a=%v f(a)=%v, b=%v f(b)=%v)", a, fa, b, fb)
+		}
+		if fa*fb < 0.0 {
+			return a, b, nil
+		}
+		if math.Abs(fa) < math.Abs(fb) {
+			a += factor * (a - b)
+			fa, e = f(a)
+		} else {
+			b += factor * (b - a)
+			fb, e = f(b)
+		}
+		if e != nil {
+			return 0, 0, e
+		}
+	}
+	if fa*fb < 0.0 {
+		return a, b, nil
+	}
+	return 0, 0, chk.Err("Bracket: could not find a bracket with a sign change after %v iterations (a=%v f(a)=%v, b=%v f(b)=%v)", maxIter, a, fa, b, fb)
+}
+
+// SolveFromGuess finds a root starting from a single guess x0, by first expanding a bracket
+// around [x0-step, x0+step] with Bracket, then running Solve on the resulting interval. This
+// is a convenience for callers that only have a rough starting point instead of a known bracket.
+func (o *Brent) SolveFromGuess(x0, step float64) (res float64, err error) {
+	if o.MaxIt == 0 {
+		o.MaxIt = 100
+	}
+	if o.Tol == 0 {
+		o.Tol = 1e-10
+	}
+	xa, xb, err := Bracket(o.Ffcn, x0-step, x0+step, o.MaxIt)
+	if err != nil {
+		return 0, err
+	}
+	return o.Solve(xa, xb, true)
+}
+
+// Min finds the minimum of f(x) within [xa, xb] using Brent's method (golden-section search
+// combined with parabolic interpolation). It does not require derivatives.
+func (o *Brent) Min(xa, xb float64, silent bool) (res float64, err error) {
+
+	const cgold = 0.3819660
+	const zeps = 1.0e-10
+
+	a, b := xa, xb
+	if a > b {
+		a, b = b, a
+	}
+	x := 0.5 * (a + b)
+	w, v := x, x
+	fx, e := o.fcn(x)
+	if e != nil {
+		return 0, e
+	}
+	fw, fv := fx, fx
+	var d, e2, etemp, p, q, r, tol1, tol2, u, fu, xm float64
+
+	for o.It = 0; o.It < o.MaxIt; o.It++ {
+		xm = 0.5 * (a + b)
+		tol1 = o.Tol*math.Abs(x) + zeps
+		tol2 = 2.0 * tol1
+		if math.Abs(x-xm) <= tol2-0.5*(b-a) {
+			if !silent {
+				io.Pforan("Brent.Min: converged with %v iterations and %v function evaluations\n", o.It, o.NFeval)
+			}
+			return x, nil
+		}
+		if math.Abs(e2) > tol1 {
+			r = (x - w) * (fx - fv)
+			q = (x - v) * (fx - fw)
+			p = (x-v)*q - (x-w)*r
+			q = 2.0 * (q - r)
+			if q > 0.0 {
+				p = -p
+			}
+			q = math.Abs(q)
+			etemp = e2
+			e2 = d
+			if math.Abs(p) >= math.Abs(0.5*q*etemp) || p <= q*(a-x) || p >= q*(b-x) {
+				if x >= xm {
+					e2 = a - x
+				} else {
+					e2 = b - x
+				}
+				d = cgold * e2
+			} else {
+				d = p / q
+				u = x + d
+				if u-a < tol2 || b-u < tol2 {
+					d = math.Copysign(tol1, xm-x)
+				}
+			}
+		} else {
+			if x >= xm {
+				e2 = a - x
+			} else {
+				e2 = b - x
+			}
+			d = cgold * e2
+		}
+		if math.Abs(d) >= tol1 {
+			u = x + d
+		} else {
+			u = x + math.Copysign(tol1, d)
+		}
+		fu, err = o.fcn(u)
+		if err != nil {
+			return 0, err
+		}
+		if fu <= fx {
+			if u >= x {
+				a = x
+			} else {
+				b = x
+			}
+			v, fv = w, fw
+			w, fw = x, fx
+			x, fx = u, fu
+		} else {
+			if u < x {
+				a = u
+			} else {
+				b = u
+			}
+			if fu <= fw || w == x {
+				v, fv = w, fw
+				w, fw = u, fu
+			} else if fu <= fv || v == x || v == w {
+				v, fv = u, fu
+			}
+		}
+	}
+	return x, chk.Err("Brent.Min: after %v iterations, minimum not found (last estimate: x=%v f(x)=%v)", o.MaxIt, x, fx)
+}
+
+// fcn calls Ffcn and increments the function evaluation counter
+func (o *Brent) fcn(x float64) (float64, error) {
+	o.NFeval++
+	return o.Ffcn(x)
+}
+
+// MinD finds the minimum of f(x) within [xa, xb] using the derivative-based variant of
+// Brent's method (a.k.a. dbrent). In addition to f, it uses the derivative dfdx, which is
+// typically available for free alongside f in many applications. At every step, secant
+// steps are tried using the values of dfdx at the current best point x and at the two
+// previous best points w and v; the secant step is only accepted when it falls inside the bracket and makes
+// sufficient progress, otherwise the method falls back to bisecting the side of the bracket
+// indicated by the sign of the derivative at the current point.
+func (o *Brent) MinD(xa, xb float64, dfdx fun.Ss) (res float64, err error) {
+
+	const zeps = 1.0e-10
+
+	a, b := xa, xb
+	if a > b {
+		a, b = b, a
+	}
+	x := 0.5 * (a + b)
+	w, v := x, x
+	fx, e := o.fcn(x)
+	if e != nil {
+		return 0, e
+	}
+	fw, fv := fx, fx
+	dx, e := o.dfcn(x, dfdx)
+	if e != nil {
+		return 0, e
+	}
+	dw, dv := dx, dx
+
+	var d, e2, d1, d2, u, fu, du, tol1, tol2, xm float64
+	var okSecant1, okSecant2 bool
+
+	for o.It = 0; o.It < o.MaxIt; o.It++ {
+		xm = 0.5 * (a + b)
+		tol1 = o.Tol*math.Abs(x) + zeps
+		tol2 = 2.0 * tol1
+		if math.Abs(x-xm) <= tol2-0.5*(b-a) {
+			return x, nil
+		}
+
+		if math.Abs(e2) > tol1 {
+			// secant step using dx and dw
+			d1 = 2.0 * (b - a)
+			d2 = d1
+			if dw != dx {
+				d1 = (w - x) * dx / (dx - dw)
+			}
+			if dv != dx {
+				d2 = (v - x) * dx / (dx - dv)
+			}
+			u1 := x + d1
+			u2 := x + d2
+			okSecant1 = (a-u1)*(u1-b) > 0.0 && dx*d1 <= 0.0
+			okSecant2 = (a-u2)*(u2-b) > 0.0 && dx*d2 <= 0.0
+			etemp := e2
+			e2 = d
+			if okSecant1 || okSecant2 {
+				if okSecant1 && okSecant2 {
+					if math.Abs(d1) < math.Abs(d2) {
+						d = d1
+					} else {
+						d = d2
+					}
+				} else if okSecant1 {
+					d = d1
+				} else {
+					d = d2
+				}
+				if math.Abs(d) <= math.Abs(0.5*etemp) {
+					u = x + d
+					if u-a < tol2 || b-u < tol2 {
+						d = math.Copysign(tol1, xm-x)
+					}
+				} else {
+					// secant step not good enough: bisect using the sign of dx
+					if dx >= 0.0 {
+						e2 = a - x
+					} else {
+						e2 = b - x
+					}
+					d = 0.5 * e2
+				}
+			} else {
+				if dx >= 0.0 {
+					e2 = a - x
+				} else {
+					e2 = b - x
+				}
+				d = 0.5 * e2
+			}
+		} else {
+			if dx >= 0.0 {
+				e2 = a - x
+			} else {
+				e2 = b - x
+			}
+			d = 0.5 * e2
+		}
+
+		if math.Abs(d) >= tol1 {
+			u = x + d
+			fu, err = o.fcn(u)
+		} else {
+			u = x + math.Copysign(tol1, d)
+			fu, err = o.fcn(u)
+			if err == nil && fu > fx {
+				// minimum step makes things worse: we are done
+				return x, nil
+			}
+		}
+		if err != nil {
+			return 0, err
+		}
+		du, err = o.dfcn(u, dfdx)
+		if err != nil {
+			return 0, err
+		}
+
+		if fu <= fx {
+			if u >= x {
+				a = x
+			} else {
+				b = x
+			}
+			v, fv, dv = w, fw, dw
+			w, fw, dw = x, fx, dx
+			x, fx, dx = u, fu, du
+		} else {
+			if u < x {
+				a = u
+			} else {
+				b = u
+			}
+			if fu <= fw || w == x {
+				v, fv, dv = w, fw, dw
+				w, fw, dw = u, fu, du
+			} else if fu <= fv || v == x || v == w {
+				v, fv, dv = u, fu, du
+			}
+		}
+	}
+	return x, chk.Err("Brent.MinD: after %v iterations, minimum not found (last estimate: x=%v f(x)=%v)", o.MaxIt, x, fx)
+}
+
+// dfcn calls the derivative function and increments the derivative evaluation counter
+func (o *Brent) dfcn(x float64, dfdx fun.Ss) (float64, error) {
+	o.NDeval++
+	return dfdx(x)
+}
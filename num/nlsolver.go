@@ -0,0 +1,391 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+)
+
+// NlGlobalization defines the globalization strategy used by NlSolver.Solve to improve
+// robustness of the plain Newton iteration
+type NlGlobalization int
+
+const (
+	// NlGlobNone performs a plain Newton step (x += d) with no globalization
+	NlGlobNone NlGlobalization = iota
+
+	// NlGlobLineSearch performs backtracking line search with the Armijo condition
+	NlGlobLineSearch
+
+	// NlGlobTrustRegion performs a Powell dogleg trust-region step
+	NlGlobTrustRegion
+)
+
+// NlSolver implements a Newton-Raphson solver for systems of nonlinear equations f(x) = 0.
+// By default (Globalization == NlGlobNone) it takes a plain Newton step, which is fast near
+// the solution but may diverge otherwise. Setting Globalization to NlGlobLineSearch or
+// NlGlobTrustRegion improves robustness at the cost of some extra function evaluations.
+type NlSolver struct {
+
+	// configuration
+	Globalization NlGlobalization // globalization strategy used by Solve
+	MaxIt         int             // max iterations
+	Atol          float64         // absolute tolerance on ‖f(x)‖
+	Armijo        float64         // Armijo's c1 constant for line search
+	TrustDelta0   float64         // initial trust-region radius
+
+	// stat
+	NFeval int // number of calls to Ffcn
+	NJeval int // number of calls to Jfcn
+	It     int // number of iterations used
+
+	// globalization stats (only meaningful after Solve when Globalization != NlGlobNone)
+	NAccepted int     // number of accepted globalization steps
+	NRejected int     // number of rejected globalization steps
+	DeltaFin  float64 // final trust-region radius (NlGlobTrustRegion only)
+
+	// problem
+	neq   int      // number of equations
+	Ffcn  fun.Vv   // f(x) vector function
+	JfcnS fun.Tv   // sparse triplet Jacobian (optional)
+	Jfcn  fun.Mv   // dense Jacobian
+	dense bool     // use dense Jacobian / solver
+	numJ  bool     // compute the Jacobian numerically
+	prms  fun.Prms // extra parameters passed to Ffcn/Jfcn
+
+	// workspace
+	fx   la.Vector
+	d    la.Vector
+	J    *la.Matrix
+	xtmp la.Vector
+	ftmp la.Vector
+}
+
+// Init configures the solver.
+//
+//	neq    -- number of equations
+//	Ffcn   -- f(x) vector function
+//	JfcnS  -- sparse triplet Jacobian function; stored but not used by Solve or CheckJ
+//	Jfcn   -- dense Jacobian function (may be nil: J is then computed numerically)
+//	dense  -- stored but not used: J is always a dense matrix solved by a dense linear solver
+//	numJ   -- compute the Jacobian numerically by finite differences (ignores JfcnS/Jfcn)
+//	prms   -- extra parameters passed to Ffcn/Jfcn (may be nil)
+func (o *NlSolver) Init(neq int, Ffcn fun.Vv, JfcnS fun.Tv, Jfcn fun.Mv, dense, numJ bool, prms fun.Prms) {
+	o.neq = neq
+	o.Ffcn = Ffcn
+	o.JfcnS = JfcnS
+	o.Jfcn = Jfcn
+	o.dense = dense
+	o.numJ = numJ
+	o.prms = prms
+	o.MaxIt = 100
+	o.Atol = 1e-9
+	o.Armijo = 1e-4
+	o.TrustDelta0 = 1.0
+	o.fx = la.NewVector(neq)
+	o.d = la.NewVector(neq)
+	o.J = la.NewMatrix(neq, neq)
+	o.xtmp = la.NewVector(neq)
+	o.ftmp = la.NewVector(neq)
+}
+
+// CheckJ compares the user-supplied (or numerical) Jacobian against a numerical Jacobian
+// computed by central differences, reporting the condition number of J along the way.
+func (o *NlSolver) CheckJ(x la.Vector, tol float64, chkJ, silent bool) (cond float64, err error) {
+	err = o.jacobian(o.J, x)
+	if err != nil {
+		return
+	}
+	if chkJ {
+		Jnum := la.NewMatrix(o.neq, o.neq)
+		h := 1e-6
+		xx := x.GetCopy()
+		f0 := la.NewVector(o.neq)
+		f1 := la.NewVector(o.neq)
+		for j := 0; j < o.neq; j++ {
+			xj := xx[j]
+			xx[j] = xj + h
+			err = o.Ffcn(f1, xx)
+			if err != nil {
+				return
+			}
+			xx[j] = xj - h
+			err = o.Ffcn(f0, xx)
+			if err != nil {
+				return
+			}
+			xx[j] = xj
+			for i := 0; i < o.neq; i++ {
+				Jnum.Set(i, j, (f1[i]-f0[i])/(2.0*h))
+			}
+		}
+		for i := 0; i < o.neq; i++ {
+			for j := 0; j < o.neq; j++ {
+				diff := math.Abs(o.J.Get(i, j) - Jnum.Get(i, j))
+				if diff > tol {
+					if !silent {
+						io.Pfred("CheckJ: J[%d][%d]=%v differs from numerical %v (diff=%v)\n", i, j, o.J.Get(i, j), Jnum.Get(i, j), diff)
+					}
+				}
+			}
+		}
+	}
+	cond = la.MatCondNum(o.J)
+	return
+}
+
+// Solve finds x such that f(x) = 0, starting from the initial guess x (modified in place).
+func (o *NlSolver) Solve(x la.Vector, silent bool) (err error) {
+	switch o.Globalization {
+	case NlGlobLineSearch:
+		return o.solveLineSearch(x, silent)
+	case NlGlobTrustRegion:
+		return o.solveTrustRegion(x, silent)
+	}
+	return o.solvePlain(x, silent)
+}
+
+// solvePlain performs plain Newton iterations: x += d where J*d = -f(x)
+func (o *NlSolver) solvePlain(x la.Vector, silent bool) (err error) {
+	for o.It = 0; o.It < o.MaxIt; o.It++ {
+		err = o.evalF(o.fx, x)
+		if err != nil {
+			return
+		}
+		if o.fx.Norm() < o.Atol {
+			if !silent {
+				io.Pforan("NlSolver.Solve: converged with %v iterations\n", o.It)
+			}
+			return nil
+		}
+		err = o.jacobian(o.J, x)
+		if err != nil {
+			return
+		}
+		err = o.newtonDirection(o.d, o.J, o.fx)
+		if err != nil {
+			return
+		}
+		for i := 0; i < o.neq; i++ {
+			x[i] += o.d[i]
+		}
+	}
+	return chk.Err("NlSolver.Solve: did not converge after %v iterations (‖f‖=%v)", o.MaxIt, o.fx.Norm())
+}
+
+// solveLineSearch performs Newton's method globalized with backtracking line search under
+// the Armijo sufficient-decrease condition: φ(λ) ≤ φ(0) + c1·λ·φ'(0), with φ(λ)=½‖f(x+λd)‖².
+func (o *NlSolver) solveLineSearch(x la.Vector, silent bool) (err error) {
+	o.NAccepted, o.NRejected = 0, 0
+	for o.It = 0; o.It < o.MaxIt; o.It++ {
+		err = o.evalF(o.fx, x)
+		if err != nil {
+			return
+		}
+		if o.fx.Norm() < o.Atol {
+			if !silent {
+				io.Pforan("NlSolver.Solve (line search): converged with %v iterations (%v accepted, %v rejected)\n", o.It, o.NAccepted, o.NRejected)
+			}
+			return nil
+		}
+		err = o.jacobian(o.J, x)
+		if err != nil {
+			return
+		}
+		err = o.newtonDirection(o.d, o.J, o.fx)
+		if err != nil {
+			return
+		}
+		phi0 := 0.5 * o.fx.Dot(o.fx)
+		dphi0 := -2.0 * phi0 // φ'(0) = -2φ(0) for the Newton direction d = -J⁻¹f
+
+		lambda := 1.0
+		for trial := 0; trial < 30; trial++ {
+			for i := 0; i < o.neq; i++ {
+				o.xtmp[i] = x[i] + lambda*o.d[i]
+			}
+			err = o.evalF(o.ftmp, o.xtmp)
+			if err != nil {
+				return
+			}
+			phi := 0.5 * o.ftmp.Dot(o.ftmp)
+			if phi <= phi0+o.Armijo*lambda*dphi0 {
+				o.NAccepted++
+				break
+			}
+			o.NRejected++
+			lambda *= 0.5
+		}
+		copy(x, o.xtmp)
+	}
+	return chk.Err("NlSolver.Solve (line search): did not converge after %v iterations (‖f‖=%v)", o.MaxIt, o.fx.Norm())
+}
+
+// solveTrustRegion performs Newton's method globalized with a Powell dogleg trust region.
+// At each step it maintains a radius Δ, computes the Cauchy point p_c = -(gᵀg/gᵀBg) g (with
+// g = Jᵀf the gradient of ½‖f‖², J the Jacobian and B = JᵀJ) and the Newton point p_n = d, then takes
+// the point along the dogleg path [0, p_c, p_n] of length Δ. The step is accepted or rejected
+// based on the ratio ρ of actual to predicted reduction, and Δ grows or shrinks accordingly.
+func (o *NlSolver) solveTrustRegion(x la.Vector, silent bool) (err error) {
+	o.NAccepted, o.NRejected = 0, 0
+	delta := o.TrustDelta0
+	if delta <= 0 {
+		delta = 1.0
+	}
+	for o.It = 0; o.It < o.MaxIt; o.It++ {
+		err = o.evalF(o.fx, x)
+		if err != nil {
+			return
+		}
+		f0norm := o.fx.Norm()
+		if f0norm < o.Atol {
+			if !silent {
+				io.Pforan("NlSolver.Solve (trust region): converged with %v iterations (%v accepted, %v rejected, Δ=%v)\n", o.It, o.NAccepted, o.NRejected, delta)
+			}
+			o.DeltaFin = delta
+			return nil
+		}
+		err = o.jacobian(o.J, x)
+		if err != nil {
+			return
+		}
+		err = o.newtonDirection(o.d, o.J, o.fx)
+		if err != nil {
+			return
+		}
+
+		// gradient of φ(x)=½‖f‖²: g = Jᵀ f
+		g := la.NewVector(o.neq)
+		la.MatTrVecMul(g, 1.0, o.J, o.fx)
+
+		// Cauchy point: p_c = -(gᵀg / gᵀBg) g, with B = JᵀJ (Gauss-Newton curvature)
+		Bg := la.NewVector(o.neq)
+		la.MatVecMul(Bg, 1.0, o.J, g)
+		gBg := Bg.Dot(Bg)
+		gg := g.Dot(g)
+		pc := la.NewVector(o.neq)
+		if gBg > 0 {
+			tau := gg / gBg
+			for i := 0; i < o.neq; i++ {
+				pc[i] = -tau * g[i]
+			}
+		}
+
+		// dogleg: pick the point of length Δ along [0, pc, pn=d]
+		step := la.NewVector(o.neq)
+		pcNorm := pc.Norm()
+		pnNorm := o.d.Norm()
+		switch {
+		case pnNorm <= delta:
+			copy(step, o.d)
+		case pcNorm >= delta:
+			for i := 0; i < o.neq; i++ {
+				step[i] = (delta / pcNorm) * pc[i]
+			}
+		default:
+			diff := la.NewVector(o.neq)
+			for i := 0; i < o.neq; i++ {
+				diff[i] = o.d[i] - pc[i]
+			}
+			a := diff.Dot(diff)
+			b := 2.0 * pc.Dot(diff)
+			c := pc.Dot(pc) - delta*delta
+			beta := (-b + math.Sqrt(b*b-4.0*a*c)) / (2.0 * a)
+			for i := 0; i < o.neq; i++ {
+				step[i] = pc[i] + beta*diff[i]
+			}
+		}
+
+		// predicted reduction (quadratic model) vs actual reduction
+		Jstep := la.NewVector(o.neq)
+		la.MatVecMul(Jstep, 1.0, o.J, step)
+		predicted := 0.5*f0norm*f0norm - 0.5*math.Pow(la.VecNorm(addVec(o.fx, Jstep)), 2)
+		for i := 0; i < o.neq; i++ {
+			o.xtmp[i] = x[i] + step[i]
+		}
+		err = o.evalF(o.ftmp, o.xtmp)
+		if err != nil {
+			return
+		}
+		actual := 0.5*f0norm*f0norm - 0.5*o.ftmp.Dot(o.ftmp)
+
+		rho := 0.0
+		if predicted > 0 {
+			rho = actual / predicted
+		}
+		if rho > 0.1 {
+			copy(x, o.xtmp)
+			o.NAccepted++
+		} else {
+			o.NRejected++
+		}
+		switch {
+		case rho < 0.25:
+			delta *= 0.25
+		case rho > 0.75 && step.Norm() >= 0.99*delta:
+			delta *= 2.0
+		}
+	}
+	o.DeltaFin = delta
+	return chk.Err("NlSolver.Solve (trust region): did not converge after %v iterations (‖f‖=%v)", o.MaxIt, o.fx.Norm())
+}
+
+// addVec returns a + b as a new vector (helper for the trust-region predicted reduction)
+func addVec(a, b la.Vector) la.Vector {
+	r := la.NewVector(len(a))
+	for i := range a {
+		r[i] = a[i] + b[i]
+	}
+	return r
+}
+
+// evalF evaluates Ffcn and increments the function evaluation counter
+func (o *NlSolver) evalF(fx, x la.Vector) error {
+	o.NFeval++
+	return o.Ffcn(fx, x)
+}
+
+// jacobian computes J(x), either analytically (via Jfcn) or numerically by forward differences
+func (o *NlSolver) jacobian(J *la.Matrix, x la.Vector) (err error) {
+	o.NJeval++
+	if !o.numJ && o.Jfcn != nil {
+		return o.Jfcn(J, x)
+	}
+	h := 1e-7
+	xx := x.GetCopy()
+	f0 := la.NewVector(o.neq)
+	f1 := la.NewVector(o.neq)
+	err = o.Ffcn(f0, xx)
+	if err != nil {
+		return
+	}
+	for j := 0; j < o.neq; j++ {
+		xj := xx[j]
+		xx[j] = xj + h
+		err = o.Ffcn(f1, xx)
+		if err != nil {
+			return
+		}
+		xx[j] = xj
+		for i := 0; i < o.neq; i++ {
+			J.Set(i, j, (f1[i]-f0[i])/h)
+		}
+	}
+	return nil
+}
+
+// newtonDirection solves J*d = -f for the Newton direction d
+func (o *NlSolver) newtonDirection(d la.Vector, J *la.Matrix, f la.Vector) (err error) {
+	neg := la.NewVector(len(f))
+	for i := range f {
+		neg[i] = -f[i]
+	}
+	return la.DenSolve(d, J, neg, false)
+}
@@ -0,0 +1,187 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+	"math/cmplx"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/la"
+)
+
+// PolyRootsMethod selects the strategy used by PolyRoots to compute all the roots of a
+// real polynomial
+type PolyRootsMethod int
+
+const (
+	// PolyRootsCompanion finds roots as the eigenvalues of the Frobenius companion matrix
+	PolyRootsCompanion PolyRootsMethod = iota
+
+	// PolyRootsLaguerre finds roots one at a time with Laguerre's method, deflating the
+	// polynomial after each root is found
+	PolyRootsLaguerre
+)
+
+// PolyRoots finds all roots (real and complex) of the real polynomial
+//
+//	p(x) = coeffs[0] + coeffs[1]*x + coeffs[2]*x^2 + ... + coeffs[n]*x^n
+//
+// Two backends are available (selected by method): PolyRootsCompanion builds the Frobenius
+// companion matrix of p and computes its eigenvalues (the roots of p) using la's Lapack
+// bindings; PolyRootsLaguerre applies Laguerre's iteration to find one root at a time,
+// deflating the polynomial by synthetic division after each root is found and polishing
+// every root against the original polynomial at the end.
+//
+// The outputs real and imag hold the real and imaginary parts of each root (len(real) ==
+// len(imag) == n == len(coeffs)-1), paired by index so that real[i]+imag[i]*1i is one root.
+func PolyRoots(coeffs []float64, method PolyRootsMethod) (real, imag []float64, err error) {
+	n := len(coeffs) - 1
+	if n < 1 {
+		return nil, nil, chk.Err("PolyRoots: polynomial must have degree >= 1 (len(coeffs)=%d)", len(coeffs))
+	}
+	if coeffs[n] == 0.0 {
+		return nil, nil, chk.Err("PolyRoots: leading coefficient coeffs[%d] must be non-zero", n)
+	}
+	switch method {
+	case PolyRootsCompanion:
+		return polyRootsCompanion(coeffs)
+	case PolyRootsLaguerre:
+		return polyRootsLaguerre(coeffs)
+	}
+	return nil, nil, chk.Err("PolyRoots: method %v is invalid", method)
+}
+
+// polyRootsCompanion builds the Frobenius companion matrix of p (1 on the subdiagonal, last
+// column holding -a_i/a_n) and returns its eigenvalues, which are exactly the roots of p.
+func polyRootsCompanion(coeffs []float64) (real, imag []float64, err error) {
+	n := len(coeffs) - 1
+	an := coeffs[n]
+	C := la.NewMatrix(n, n)
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			C.Set(i, i-1, 1.0)
+		}
+		C.Set(i, n-1, -coeffs[i]/an)
+	}
+	real, imag, err = la.Eigenvalues(C)
+	if err != nil {
+		return nil, nil, chk.Err("PolyRoots: companion matrix eigenvalue computation failed: %v", err)
+	}
+	return
+}
+
+// polyRootsLaguerre finds each root with Laguerre's iteration, deflating the polynomial by
+// synthetic division after every root is found, then polishes the roots against the
+// original (non-deflated) polynomial.
+func polyRootsLaguerre(coeffs []float64) (real, imag []float64, err error) {
+	n := len(coeffs) - 1
+	orig := make([]complex128, n+1)
+	for i, c := range coeffs {
+		orig[i] = complex(c, 0)
+	}
+
+	// work on a copy that gets deflated
+	work := make([]complex128, n+1)
+	copy(work, orig)
+
+	roots := make([]complex128, n)
+	for k := n; k >= 1; k-- {
+		x0 := complex(0.0, 0.0) // start Laguerre from the origin
+		x, e := laguerreOneRoot(work[:k+1], x0)
+		if e != nil {
+			return nil, nil, e
+		}
+		// clean tiny imaginary parts coming from real roots
+		if math.Abs(imagPart(x)) < 1e-12*(1.0+math.Abs(realPart(x))) {
+			x = complex(realPart(x), 0)
+		}
+		roots[k-1] = x
+		deflate(work[:k+1], x)
+	}
+
+	// polish every root against the original polynomial
+	for i := range roots {
+		x, e := laguerreOneRoot(orig, roots[i])
+		if e == nil {
+			roots[i] = x
+		}
+	}
+
+	real = make([]float64, n)
+	imag = make([]float64, n)
+	for i, r := range roots {
+		real[i] = realPart(r)
+		imag[i] = imagPart(r)
+	}
+	return
+}
+
+// laguerreOneRoot applies Laguerre's method to find one root of the polynomial given by
+// coeffs (coeffs[0] is the constant term), starting from x0.
+func laguerreOneRoot(coeffs []complex128, x0 complex128) (x complex128, err error) {
+	const maxIt = 100
+	const eps = 1.0e-14
+	const multTol = 1.0e-7 // relative tolerance used to flag a near-vanishing denominator (near-multiple root)
+	n := len(coeffs) - 1
+	x = x0
+	for it := 0; it < maxIt; it++ {
+
+		// Horner evaluation of p, p' and p'' at x
+		p, dp, ddp := coeffs[n], complex(0, 0), complex(0, 0)
+		for j := n - 1; j >= 0; j-- {
+			ddp = ddp*x + dp
+			dp = dp*x + p
+			p = p*x + coeffs[j]
+		}
+		if cmplx.Abs(p) < eps {
+			return x, nil
+		}
+
+		// Laguerre step: a = n / (G ± sqrt((n-1)(nH-G^2)))
+		// note: the Horner recurrence above leaves ddp = p''/2, hence the factor of 2 below
+		nc := complex(float64(n), 0)
+		G := dp / p
+		H := G*G - 2.0*ddp/p
+		disc := cmplx.Sqrt(complex(float64(n-1), 0) * (nc*H - G*G))
+		denomPlus := G + disc
+		denomMinus := G - disc
+		denom := denomPlus
+		if cmplx.Abs(denomMinus) > cmplx.Abs(denomPlus) {
+			denom = denomMinus
+		}
+		// the larger of |G+disc| and |G-disc| is tiny only when G and disc are both close to
+		// zero; the step n/denom is then unusable and this is reported as a near-multiple root.
+		// A small disc alone (with |G| not small) is not flagged.
+		denomTol := multTol * (1.0 + cmplx.Abs(G))
+		if cmplx.Abs(denom) < denomTol {
+			return x, chk.Err("Laguerre: near-multiple root detected (denominator ~%v too small) around x=%v", cmplx.Abs(denom), x)
+		}
+		a := nc / denom
+		xNew := x - a
+		if cmplx.Abs(xNew-x) < eps*cmplx.Abs(xNew) {
+			return xNew, nil
+		}
+		x = xNew
+	}
+	return x, chk.Err("Laguerre: did not converge after %d iterations (last estimate: x=%v)", maxIt, x)
+}
+
+// deflate divides coeffs (in place, up to its current degree) by (x - root) using synthetic
+// division, shrinking the effective degree by one.
+func deflate(coeffs []complex128, root complex128) {
+	n := len(coeffs) - 1
+	b := coeffs[n]
+	for j := n - 1; j >= 0; j-- {
+		c := coeffs[j]
+		coeffs[j] = b
+		b = c + root*b
+	}
+}
+
+// realPart and imagPart wrap the built-in real()/imag() so they remain usable inside
+// functions whose named return values are themselves called "real" and "imag".
+func realPart(z complex128) float64 { return real(z) }
+func imagPart(z complex128) float64 { return imag(z) }
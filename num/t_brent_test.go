@@ -167,3 +167,38 @@ func Test_brent03(tst *testing.T) {
 	//save := true
 	chk.Scalar(tst, "xcorrect", 1e-8, x, xcor)
 }
+
+func Test_brent04(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("brent04. minimum finding with derivative (dbrent)")
+
+	ffcn := func(x float64) (res float64, err error) {
+		return x*x*x - 2.0*x - 5.0, nil
+	}
+	dfcn := func(x float64) (res float64, err error) {
+		return 3.0*x*x - 2.0, nil
+	}
+
+	var o Brent
+	o.Init(ffcn)
+	xa, xb := 0.0, 1.0
+	x, err := o.MinD(xa, xb, dfcn)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	y, err := ffcn(x)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	xcor := math.Sqrt(2.0 / 3.0)
+	io.Pforan("x      = %v (correct=%g)\n", x, xcor)
+	io.Pforan("f(x)   = %v\n", y)
+	io.Pforan("nfeval = %v\n", o.NFeval)
+	io.Pforan("ndeval = %v\n", o.NDeval)
+	io.Pforan("nit    = %v\n", o.It)
+
+	chk.Scalar(tst, "xcorrect", 1e-8, x, xcor)
+}
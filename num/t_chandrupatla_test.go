@@ -0,0 +1,157 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func Test_chandrupatla01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("chandrupatla01. root finding")
+
+	ffcn := func(x float64) (res float64, err error) {
+		res = math.Pow(x, 3.0) - 0.165*math.Pow(x, 2.0) + 3.993e-4
+		return
+	}
+
+	var o Chandrupatla
+	o.Init(ffcn)
+	x, err := o.Solve(0.0, 0.11, false)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	y, err := ffcn(x)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("x      = %v\n", x)
+	io.Pforan("f(x)   = %v\n", y)
+	io.Pforan("nfeval = %v\n", o.NFeval)
+	io.Pforan("nit    = %v\n", o.It)
+	if math.Abs(y) > 1e-10 {
+		tst.Errorf("Chandrupatla failed: f(x) = %g > 1e-10\n", y)
+	}
+	if o.NFeval > 25 {
+		tst.Errorf("Chandrupatla should need far fewer evaluations than bisection here: nfeval=%d\n", o.NFeval)
+	}
+}
+
+func Test_chandrupatla02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("chandrupatla02. root finding")
+
+	ffcn := func(x float64) (res float64, err error) {
+		return x*x*x - 2.0*x - 5.0, nil
+	}
+
+	var o Chandrupatla
+	o.Init(ffcn)
+	x, err := o.Solve(2.0, 3.0, false)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("x      = %v\n", x)
+	io.Pforan("nfeval = %v\n", o.NFeval)
+	io.Pforan("nit    = %v\n", o.It)
+	chk.Scalar(tst, "xsol", 1e-9, x, 2.09455148154233)
+	if o.NFeval > 25 {
+		tst.Errorf("Chandrupatla should need far fewer evaluations than bisection here: nfeval=%d\n", o.NFeval)
+	}
+}
+
+func Test_chandrupatla03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("chandrupatla03. root finding with a non-polynomial, asymmetric bracket")
+
+	// root of cos(x) - x on [-2, 1], away from the bracket's midpoint
+	ffcn := func(x float64) (res float64, err error) {
+		return math.Cos(x) - x, nil
+	}
+
+	var o Chandrupatla
+	o.Init(ffcn)
+	x, err := o.Solve(-2.0, 1.0, false)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	y, err := ffcn(x)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("x      = %v\n", x)
+	io.Pforan("f(x)   = %v\n", y)
+	io.Pforan("nfeval = %v\n", o.NFeval)
+	io.Pforan("nit    = %v\n", o.It)
+	chk.Scalar(tst, "xsol", 1e-9, x, 0.7390851332151607)
+	if o.NFeval > 25 {
+		tst.Errorf("Chandrupatla should need far fewer evaluations than bisection here: nfeval=%d\n", o.NFeval)
+	}
+}
+
+func Test_toms748a(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("toms748a. root finding")
+
+	ffcn := func(x float64) (res float64, err error) {
+		res = math.Pow(x, 3.0) - 0.165*math.Pow(x, 2.0) + 3.993e-4
+		return
+	}
+
+	var o Toms748
+	o.Init(ffcn)
+	x, err := o.Solve(0.0, 0.11, false)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	y, err := ffcn(x)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("x      = %v\n", x)
+	io.Pforan("f(x)   = %v\n", y)
+	io.Pforan("nfeval = %v\n", o.NFeval)
+	io.Pforan("nit    = %v\n", o.It)
+	if math.Abs(y) > 1e-10 {
+		tst.Errorf("Toms748 failed: f(x) = %g > 1e-10\n", y)
+	}
+}
+
+func Test_toms748b(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("toms748b. root finding")
+
+	ffcn := func(x float64) (res float64, err error) {
+		return x*x*x - 2.0*x - 5.0, nil
+	}
+
+	var o Toms748
+	o.Init(ffcn)
+	x, err := o.Solve(2.0, 3.0, false)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("x      = %v\n", x)
+	io.Pforan("nfeval = %v\n", o.NFeval)
+	io.Pforan("nit    = %v\n", o.It)
+	chk.Scalar(tst, "xsol", 1e-9, x, 2.09455148154233)
+}
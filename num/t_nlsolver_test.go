@@ -0,0 +1,101 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+)
+
+// same cubic as Test_brent01; xguess=0.001 is the guess for which plain Newton (see the
+// comment in Test_brent01) converges to the right-hand root (x≈0.146) instead of the one
+// Brent finds in [0, 0.11], making it a good stress test
+// for the globalization strategies below
+func nlsolverCubicProblem() (ffcnA func(x float64) (float64, error), ffcnB func(fx, x la.Vector) error, JfcnB func(dfdx *la.Matrix, x la.Vector) error) {
+	ffcnA = func(x float64) (res float64, err error) {
+		res = math.Pow(x, 3.0) - 0.165*math.Pow(x, 2.0) + 3.993e-4
+		return
+	}
+	ffcnB = func(fx, x la.Vector) (err error) {
+		fx[0], err = ffcnA(x[0])
+		return
+	}
+	JfcnB = func(dfdx *la.Matrix, x la.Vector) (err error) {
+		dfdx.Set(0, 0, 3.0*x[0]*x[0]-2.0*0.165*x[0])
+		return
+	}
+	return
+}
+
+func Test_nlsolver01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("nlsolver01. line search globalization")
+
+	_, ffcnB, JfcnB := nlsolverCubicProblem()
+
+	var p NlSolver
+	p.Init(1, ffcnB, nil, JfcnB, true, false, nil)
+	p.Globalization = NlGlobLineSearch
+	x := la.NewVector(1)
+	x[0] = 0.001
+	err := p.Solve(x, false)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("x         = %v\n", x[0])
+	io.Pforan("nit       = %v\n", p.It)
+	io.Pforan("naccepted = %v\n", p.NAccepted)
+	io.Pforan("nrejected = %v\n", p.NRejected)
+	if p.NAccepted < 1 {
+		tst.Errorf("line search should have accepted at least one step\n")
+	}
+
+	root := math.Pow(x[0], 3.0) - 0.165*math.Pow(x[0], 2.0) + 3.993e-4
+	if math.Abs(root) > 1e-9 {
+		tst.Errorf("NlSolver (line search) failed: f(x) = %g > 1e-9\n", root)
+	}
+}
+
+func Test_nlsolver02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("nlsolver02. trust-region (Powell dogleg) globalization")
+
+	_, ffcnB, JfcnB := nlsolverCubicProblem()
+
+	var p NlSolver
+	p.Init(1, ffcnB, nil, JfcnB, true, false, nil)
+	p.Globalization = NlGlobTrustRegion
+	p.TrustDelta0 = 0.05
+	x := la.NewVector(1)
+	x[0] = 0.001
+	err := p.Solve(x, false)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("x         = %v\n", x[0])
+	io.Pforan("nit       = %v\n", p.It)
+	io.Pforan("naccepted = %v\n", p.NAccepted)
+	io.Pforan("nrejected = %v\n", p.NRejected)
+	io.Pforan("deltafin  = %v\n", p.DeltaFin)
+	if p.NAccepted < 1 {
+		tst.Errorf("trust region should have accepted at least one step\n")
+	}
+	if p.DeltaFin <= 0.0 {
+		tst.Errorf("trust region should report a positive final radius\n")
+	}
+
+	root := math.Pow(x[0], 3.0) - 0.165*math.Pow(x[0], 2.0) + 3.993e-4
+	if math.Abs(root) > 1e-9 {
+		tst.Errorf("NlSolver (trust region) failed: f(x) = %g > 1e-9\n", root)
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func Test_polyroots01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("polyroots01. companion matrix. cubic with known real root")
+
+	// p(x) = x^3 - 0.165 x^2 + 3.993e-4, same cubic as Test_brent01
+	coeffs := []float64{3.993e-4, 0.0, -0.165, 1.0}
+
+	real, imag, err := PolyRoots(coeffs, PolyRootsCompanion)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("real = %v\n", real)
+	io.Pforan("imag = %v\n", imag)
+
+	found := false
+	for i := range real {
+		if math.Abs(imag[i]) < 1e-8 && math.Abs(real[i]-0.06237758151374953) < 1e-6 {
+			found = true
+		}
+	}
+	if !found {
+		tst.Errorf("did not find the expected root near x=0.06237758151374953\n")
+	}
+}
+
+func Test_polyroots02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("polyroots02. laguerre. cubic with one real root")
+
+	// p(x) = x^3 - 2x - 5, same cubic as Test_brent02 (real root = 2.09455148154233)
+	coeffs := []float64{-5.0, -2.0, 0.0, 1.0}
+
+	real, imag, err := PolyRoots(coeffs, PolyRootsLaguerre)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("real = %v\n", real)
+	io.Pforan("imag = %v\n", imag)
+
+	found := false
+	for i := range real {
+		if math.Abs(imag[i]) < 1e-8 {
+			chk.Scalar(tst, "xsol", 1e-8, real[i], 2.09455148154233)
+			found = true
+		}
+	}
+	if !found {
+		tst.Errorf("did not find the expected real root\n")
+	}
+}
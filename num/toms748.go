@@ -0,0 +1,149 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun"
+	"github.com/cpmech/gosl/io"
+)
+
+// Toms748 implements the TOMS 748 algorithm (Alefeld, Potra & Shi) for finding a root of
+// f(x) = 0 within a bracket [xa, xb] with f(xa)*f(xb) < 0. It has the same Init/Solve surface
+// as Brent, so it is a drop-in alternative. Whenever four distinct function values are on
+// hand it uses inverse cubic interpolation, falling back to inverse quadratic interpolation
+// with fewer points, and enforces a bracket-halving (bisection) step every two iterations so
+// that the bracket is at least halved every two iterations (in the worst case about twice the
+// iterations of plain bisection).
+type Toms748 struct {
+
+	// constants
+	MaxIt int     // max iterations
+	Tol   float64 // tolerance
+
+	// stat
+	NFeval int // number of calls to Ffcn
+	It     int // number of iterations used
+
+	// data
+	Ffcn fun.Ss // y = f(x)
+}
+
+// Init sets the function and default constants
+func (o *Toms748) Init(ffcn fun.Ss) {
+	o.Ffcn = ffcn
+	o.MaxIt = 100
+	o.Tol = 1e-10
+}
+
+// Solve finds the root of f(x) within [xa, xb] using the TOMS 748 algorithm.
+func (o *Toms748) Solve(xa, xb float64, silent bool) (res float64, err error) {
+
+	a, b := xa, xb
+	fa, e := o.fcn(a)
+	if e != nil {
+		return 0, e
+	}
+	fb, e := o.fcn(b)
+	if e != nil {
+		return 0, e
+	}
+	if fa*fb > 0.0 {
+		return 0, chk.Err("root must be bracketed. f(xa)=%v and f(xb)=%v have the same sign", fa, fb)
+	}
+
+	// d, e are the two most recently discarded points, used to build up to a 4-point stencil
+	d, fd := a, fa
+	haveD := false
+	var ePt, fe float64
+	haveE := false
+
+	for o.It = 0; o.It < o.MaxIt; o.It++ {
+
+		tol1 := 2.0*2.2204460492503131e-16*math.Abs(b) + 0.5*o.Tol
+		if math.Abs(b-a) <= tol1 || fb == 0.0 {
+			if !silent {
+				io.Pforan("Toms748.Solve: converged with %v iterations and %v function evaluations\n", o.It, o.NFeval)
+			}
+			return b, nil
+		}
+
+		var c float64
+		useBisect := o.It%2 == 1 // enforce a guaranteed bracket-halving step every two iterations
+		if !useBisect && haveD && haveE && allDistinct(fa, fb, fd, fe) {
+			c = inverseCubic(a, fa, b, fb, d, fd, ePt, fe)
+		} else if !useBisect && haveD && fa != fd && fb != fd {
+			c = inverseQuadratic(a, fa, b, fb, d, fd)
+		} else {
+			c = 0.5 * (a + b)
+		}
+
+		// keep the interpolated point strictly inside (a,b); otherwise bisect
+		lo, hi := math.Min(a, b), math.Max(a, b)
+		if math.IsNaN(c) || c <= lo || c >= hi {
+			c = 0.5 * (a + b)
+		}
+
+		fc, e := o.fcn(c)
+		if e != nil {
+			return 0, e
+		}
+
+		// shuffle the discarded-points history: e <- d <- whichever of {a,b} is replaced
+		if sameSign(fc, fa) {
+			ePt, fe, haveE = d, fd, haveD
+			d, fd, haveD = a, fa, true
+			a, fa = c, fc
+		} else {
+			ePt, fe, haveE = d, fd, haveD
+			d, fd, haveD = b, fb, true
+			b, fb = c, fc
+		}
+
+		// maintain b as the best (smaller |f|) estimate
+		if math.Abs(fa) < math.Abs(fb) {
+			a, b = b, a
+			fa, fb = fb, fa
+		}
+	}
+	return 0, chk.Err("Toms748.Solve: after %v iterations, no root found (last estimate: x=%v f(x)=%v)", o.MaxIt, b, fb)
+}
+
+// fcn calls Ffcn and increments the function evaluation counter
+func (o *Toms748) fcn(x float64) (float64, error) {
+	o.NFeval++
+	return o.Ffcn(x)
+}
+
+// inverseQuadratic fits a quadratic through three points (in terms of f -> x) and returns
+// its estimate of the root
+func inverseQuadratic(a, fa, b, fb, d, fd float64) float64 {
+	return a*fb*fd/((fa-fb)*(fa-fd)) + b*fa*fd/((fb-fa)*(fb-fd)) + d*fa*fb/((fd-fa)*(fd-fb))
+}
+
+// inverseCubic fits a cubic through four points (in terms of f -> x) and returns its
+// estimate of the root
+func inverseCubic(a, fa, b, fb, d, fd, e, fe float64) float64 {
+	q11 := (d - e) * fd / (fe - fd)
+	q21 := (b - d) * fb / (fd - fb)
+	q31 := (a - b) * fa / (fb - fa)
+	d21 := (b - d) * fd / (fd - fb)
+	d31 := (a - b) * fb / (fb - fa)
+
+	q22 := (d21 - q11) * fb / (fe - fb)
+	q32 := (d31 - q21) * fa / (fd - fa)
+	d32 := (d31 - q21) * fd / (fd - fa)
+	q33 := (d32 - q22) * fa / (fe - fa)
+
+	return a + q31 + q32 + q33
+}
+
+// allDistinct returns true when all four function values are pairwise distinct, a
+// precondition for inverse cubic interpolation to be well defined
+func allDistinct(fa, fb, fd, fe float64) bool {
+	return fa != fb && fa != fd && fa != fe && fb != fd && fb != fe && fd != fe
+}